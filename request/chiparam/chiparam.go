@@ -0,0 +1,19 @@
+// Package chiparam adapts github.com/go-chi/chi/v5 route params for use
+// with request.ParamExtractor. It's a separate module from the core
+// request package so that depending on it is opt-in: the core package
+// doesn't pick up chi as a hard dependency just to support it as a router.
+package chiparam
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// URLParamLookup is a request.ParamLookup backed by chi.URLParam, for routes
+// registered on a chi.Router, e.g. "/download/{token}".
+//
+//	request.ParamExtractor{Names: []string{"token"}, Lookup: chiparam.URLParamLookup}
+func URLParamLookup(req *http.Request, name string) string {
+	return chi.URLParam(req, name)
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCookieExtractor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		extractor CookieExtractor
+		cookies   map[string]string
+		wantToken string
+		wantErr   error
+	}{
+		{
+			name:      "first configured cookie matches",
+			extractor: CookieExtractor{"jwt", "session"},
+			cookies:   map[string]string{"jwt": "tok-1"},
+			wantToken: "tok-1",
+		},
+		{
+			name:      "later configured cookie matches",
+			extractor: CookieExtractor{"jwt", "session"},
+			cookies:   map[string]string{"session": "tok-2"},
+			wantToken: "tok-2",
+		},
+		{
+			name:      "empty cookie value is skipped",
+			extractor: CookieExtractor{"jwt"},
+			cookies:   map[string]string{"jwt": ""},
+			wantErr:   ErrNoTokenInRequest,
+		},
+		{
+			name:      "no matching cookie",
+			extractor: CookieExtractor{"jwt"},
+			cookies:   map[string]string{"other": "tok"},
+			wantErr:   ErrNoTokenInRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tc.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+
+			tok, err := tc.extractor.ExtractToken(req)
+			if err != tc.wantErr {
+				t.Fatalf("ExtractToken() error = %v, want %v", err, tc.wantErr)
+			}
+			if tok != tc.wantToken {
+				t.Fatalf("ExtractToken() = %q, want %q", tok, tc.wantToken)
+			}
+		})
+	}
+}
+
+func TestQueryExtractor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		extractor QueryExtractor
+		url       string
+		body      string
+		wantToken string
+		wantErr   error
+	}{
+		{
+			name:      "matches configured query param",
+			extractor: QueryExtractor{"access_token"},
+			url:       "/?access_token=tok-1",
+			wantToken: "tok-1",
+		},
+		{
+			name:      "no matching param",
+			extractor: QueryExtractor{"access_token"},
+			url:       "/?other=tok",
+			wantErr:   ErrNoTokenInRequest,
+		},
+		{
+			name:      "does not read form-encoded POST body",
+			extractor: QueryExtractor{"access_token"},
+			url:       "/",
+			body:      "access_token=tok-1",
+			wantErr:   ErrNoTokenInRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.body != "" {
+				req = httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			tok, err := tc.extractor.ExtractToken(req)
+			if err != tc.wantErr {
+				t.Fatalf("ExtractToken() error = %v, want %v", err, tc.wantErr)
+			}
+			if tok != tc.wantToken {
+				t.Fatalf("ExtractToken() = %q, want %q", tok, tc.wantToken)
+			}
+			if req.Form != nil {
+				t.Fatalf("QueryExtractor must not parse the request body, but req.Form = %v", req.Form)
+			}
+		})
+	}
+}
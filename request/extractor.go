@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"unicode"
 )
 
 // Errors
@@ -51,6 +52,40 @@ func (e ArgumentExtractor) ExtractToken(req *http.Request) (string, error) {
 	return "", ErrNoTokenInRequest
 }
 
+// CookieExtractor is an extractor for finding a token in a cookie.
+// Looks at each specified cookie name in order until there's a match
+type CookieExtractor []string
+
+func (e CookieExtractor) ExtractToken(req *http.Request) (string, error) {
+	// loop over cookie names and return the first one that contains data
+	for _, name := range e {
+		if c, err := req.Cookie(name); err == nil && c.Value != "" {
+			return c.Value, nil
+		}
+	}
+	return "", ErrNoTokenInRequest
+}
+
+// QueryExtractor extracts a token from the request's URL query string.
+// Unlike ArgumentExtractor, this never parses the request body, so it's
+// safe to use on requests (such as websocket upgrades) where the body
+// must be left untouched. Argument names are tried in order until there's
+// a match.
+type QueryExtractor []string
+
+func (e QueryExtractor) ExtractToken(req *http.Request) (string, error) {
+	query := req.URL.Query()
+
+	// loop over arg names and return the first one that contains data
+	for _, arg := range e {
+		if ah := query.Get(arg); ah != "" {
+			return ah, nil
+		}
+	}
+
+	return "", ErrNoTokenInRequest
+}
+
 // MultiExtractor tries Extractors in order until one returns a token string or an error occurs
 type MultiExtractor []Extractor
 
@@ -81,17 +116,49 @@ func (e *PostExtractionFilter) ExtractToken(req *http.Request) (string, error) {
 	}
 }
 
-// BearerExtractor extracts a token from the Authorization header.
-// The header is expected to match the format "Bearer XX", where "XX" is the
-// JWT token.
-type BearerExtractor struct{}
+// SchemeExtractor extracts a token from a header formatted as "<Scheme> <token>",
+// e.g. the Authorization header's "Bearer XYZ". Header defaults to
+// "Authorization" and Scheme defaults to "Bearer" when left unset, so the
+// zero value SchemeExtractor{} behaves like a plain bearer-token extractor.
+// Setting Scheme to "DPoP", "Token", or another value supports emerging
+// auth schemes without needing a custom PostExtractionFilter.
+type SchemeExtractor struct {
+	Header string
+	Scheme string
+}
+
+// BearerExtractor is a compatibility alias for SchemeExtractor, which
+// replaced it as a configurable type. BearerExtractor{} is still a valid,
+// zero-value bearer-token extractor.
+//
+// Deprecated: use SchemeExtractor directly.
+type BearerExtractor = SchemeExtractor
+
+func (e SchemeExtractor) ExtractToken(req *http.Request) (string, error) {
+	header := e.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	scheme := e.Scheme
+	if scheme == "" {
+		scheme = "Bearer"
+	}
+
+	tokenHeader := req.Header.Get(header)
+	if len(tokenHeader) < len(scheme)+2 {
+		return "", ErrNoTokenInRequest
+	}
+
+	// Split on the first run of whitespace rather than assuming a single
+	// space, so "Bearer\tXYZ" and "Bearer  XYZ" are both accepted.
+	idx := strings.IndexFunc(tokenHeader, unicode.IsSpace)
+	if idx < 0 || !strings.EqualFold(tokenHeader[:idx], scheme) {
+		return "", ErrNoTokenInRequest
+	}
 
-func (e BearerExtractor) ExtractToken(req *http.Request) (string, error) {
-	tokenHeader := req.Header.Get("Authorization")
-	// The usual convention is for "Bearer" to be title-cased. However, there's no
-	// strict rule around this, and it's best to follow the robustness principle here.
-	if len(tokenHeader) < 7 || !strings.HasPrefix(strings.ToLower(tokenHeader[:7]), "bearer ") {
+	tok := strings.TrimLeftFunc(tokenHeader[idx:], unicode.IsSpace)
+	if tok == "" {
 		return "", ErrNoTokenInRequest
 	}
-	return tokenHeader[7:], nil
+	return tok, nil
 }
@@ -0,0 +1,106 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSchemeExtractor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		extractor SchemeExtractor
+		header    string
+		wantToken string
+		wantErr   error
+	}{
+		{
+			name:      "zero value behaves like a bearer extractor",
+			extractor: SchemeExtractor{},
+			header:    "Bearer XYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:      "lower-case scheme is accepted",
+			extractor: SchemeExtractor{},
+			header:    "bearer XYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:      "tab between scheme and token",
+			extractor: SchemeExtractor{},
+			header:    "bearer\tXYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:      "double space between scheme and token",
+			extractor: SchemeExtractor{},
+			header:    "Bearer  XYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:      "custom scheme",
+			extractor: SchemeExtractor{Scheme: "DPoP"},
+			header:    "DPoP XYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:      "custom header and scheme",
+			extractor: SchemeExtractor{Header: "X-API-Token", Scheme: "Token"},
+			header:    "Token XYZ",
+			wantToken: "XYZ",
+		},
+		{
+			name:    "wrong scheme",
+			header:  "Basic XYZ",
+			wantErr: ErrNoTokenInRequest,
+		},
+		{
+			name:    "missing token after scheme",
+			header:  "Bearer ",
+			wantErr: ErrNoTokenInRequest,
+		},
+		{
+			name:    "no whitespace at all",
+			header:  "BearerXYZ",
+			wantErr: ErrNoTokenInRequest,
+		},
+		{
+			name:    "header shorter than scheme",
+			header:  "Be",
+			wantErr: ErrNoTokenInRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			header := tc.extractor.Header
+			if header == "" {
+				header = "Authorization"
+			}
+			req.Header.Set(header, tc.header)
+
+			tok, err := tc.extractor.ExtractToken(req)
+			if err != tc.wantErr {
+				t.Fatalf("ExtractToken() error = %v, want %v", err, tc.wantErr)
+			}
+			if tok != tc.wantToken {
+				t.Fatalf("ExtractToken() = %q, want %q", tok, tc.wantToken)
+			}
+		})
+	}
+}
+
+func TestBearerExtractorIsSchemeExtractor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer XYZ")
+
+	tok, err := BearerExtractor{}.ExtractToken(req)
+	if err != nil {
+		t.Fatalf("ExtractToken() unexpected error: %v", err)
+	}
+	if tok != "XYZ" {
+		t.Fatalf("ExtractToken() = %q, want %q", tok, "XYZ")
+	}
+}
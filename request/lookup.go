@@ -0,0 +1,90 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExtractorLimit is the maximum number of extractors NewExtractor will build
+// from a single lookup spec. This bounds the amount of work a malicious or
+// mistaken config string can force onto every request.
+const ExtractorLimit = 20
+
+// NewExtractor builds an Extractor from a declarative, comma-separated lookup
+// spec of the form "source:name", e.g.
+//
+//	"header:Authorization,cookie:jwt,query:access_token,form:token"
+//
+// Recognized sources are "header", "cookie", "query", and "form". When source
+// is "header" and name case-insensitively matches "Authorization", the
+// resulting extractor is a SchemeExtractor that strips the authScheme prefix
+// (authScheme defaults to "Bearer" when empty). A header entry may also
+// specify its own scheme to strip with a three-part form, "header:name:scheme",
+// e.g. "header:X-API-Token:Token".
+//
+// NewExtractor returns an error if the spec is empty, contains a malformed
+// segment, names an unknown source, or has more than ExtractorLimit
+// comma-separated segments (empty segments are skipped but still count
+// toward the limit).
+func NewExtractor(lookup, authScheme string) (Extractor, error) {
+	if authScheme == "" {
+		authScheme = "Bearer"
+	}
+
+	segments := strings.Split(lookup, ",")
+	if len(segments) > ExtractorLimit {
+		return nil, fmt.Errorf("request: lookup spec has %d segments, exceeds limit of %d", len(segments), ExtractorLimit)
+	}
+
+	var extractors MultiExtractor
+	for _, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+
+		fields := strings.SplitN(segment, ":", 3)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("request: malformed lookup segment %q, expected \"source:name\"", segment)
+		}
+
+		source := strings.ToLower(strings.TrimSpace(fields[0]))
+		name := strings.TrimSpace(fields[1])
+		if name == "" {
+			return nil, fmt.Errorf("request: malformed lookup segment %q, missing name", segment)
+		}
+
+		var extractor Extractor
+		switch source {
+		case "header":
+			scheme := ""
+			switch {
+			case len(fields) == 3:
+				scheme = strings.TrimSpace(fields[2])
+			case strings.EqualFold(name, "Authorization"):
+				scheme = authScheme
+			}
+			if scheme != "" {
+				extractor = SchemeExtractor{Header: name, Scheme: scheme}
+			} else {
+				extractor = HeaderExtractor{name}
+			}
+		case "cookie":
+			extractor = CookieExtractor{name}
+		case "query":
+			extractor = QueryExtractor{name}
+		case "form":
+			extractor = ArgumentExtractor{name}
+		default:
+			return nil, fmt.Errorf("request: unknown lookup source %q", source)
+		}
+
+		extractors = append(extractors, extractor)
+	}
+
+	if len(extractors) == 0 {
+		return nil, fmt.Errorf("request: lookup spec %q contains no extractors", lookup)
+	}
+
+	return extractors, nil
+}
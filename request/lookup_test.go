@@ -0,0 +1,130 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewExtractor(t *testing.T) {
+	testCases := []struct {
+		name       string
+		lookup     string
+		authScheme string
+		buildReq   func() *http.Request
+		wantToken  string
+		wantErr    bool
+	}{
+		{
+			name:   "header source with default Authorization scheme",
+			lookup: "header:Authorization",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Authorization", "Bearer tok-1")
+				return req
+			},
+			wantToken: "tok-1",
+		},
+		{
+			name:       "header source with custom auth scheme",
+			lookup:     "header:Authorization",
+			authScheme: "DPoP",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("Authorization", "DPoP tok-2")
+				return req
+			},
+			wantToken: "tok-2",
+		},
+		{
+			name:   "three-part header form with its own scheme",
+			lookup: "header:X-API-Token:Token",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.Header.Set("X-API-Token", "Token tok-3")
+				return req
+			},
+			wantToken: "tok-3",
+		},
+		{
+			name:   "falls through comma-separated sources in order",
+			lookup: "header:Authorization,cookie:jwt,query:access_token,form:token",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/?access_token=tok-4", nil)
+				return req
+			},
+			wantToken: "tok-4",
+		},
+		{
+			name:   "cookie source",
+			lookup: "cookie:jwt",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.AddCookie(&http.Cookie{Name: "jwt", Value: "tok-5"})
+				return req
+			},
+			wantToken: "tok-5",
+		},
+		{
+			name:   "form source",
+			lookup: "form:token",
+			buildReq: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("token=tok-6"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				return req
+			},
+			wantToken: "tok-6",
+		},
+		{
+			name:    "malformed segment",
+			lookup:  "header",
+			wantErr: true,
+		},
+		{
+			name:    "unknown source",
+			lookup:  "bogus:name",
+			wantErr: true,
+		},
+		{
+			name:    "empty spec",
+			lookup:  "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extractor, err := NewExtractor(tc.lookup, tc.authScheme)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("NewExtractor(%q) error = nil, want error", tc.lookup)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewExtractor(%q) unexpected error: %v", tc.lookup, err)
+			}
+
+			tok, err := extractor.ExtractToken(tc.buildReq())
+			if err != nil {
+				t.Fatalf("ExtractToken() unexpected error: %v", err)
+			}
+			if tok != tc.wantToken {
+				t.Fatalf("ExtractToken() = %q, want %q", tok, tc.wantToken)
+			}
+		})
+	}
+}
+
+func TestNewExtractorRejectsOversizedSpec(t *testing.T) {
+	segments := make([]string, ExtractorLimit+1)
+	for i := range segments {
+		segments[i] = "header:X"
+	}
+	lookup := strings.Join(segments, ",")
+
+	if _, err := NewExtractor(lookup, ""); err == nil {
+		t.Fatalf("NewExtractor() with %d segments: error = nil, want error", len(segments))
+	}
+}
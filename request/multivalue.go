@@ -0,0 +1,174 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// MultiValueExtractor is an extractor that can return more than one
+// candidate token from a single request, e.g. when a proxy injects an
+// extra Authorization header, or both a header and a cookie carry a token.
+// Callers verifying a token should try each candidate in turn and use the
+// first one that verifies; this is particularly useful during key rotation.
+// ExtractTokens must return ErrNoTokenInRequest if no candidate is found.
+type MultiValueExtractor interface {
+	ExtractTokens(*http.Request) ([]string, error)
+}
+
+// singleValueAdapter adapts an Extractor to MultiValueExtractor, returning
+// at most one candidate token.
+type singleValueAdapter struct {
+	Extractor
+}
+
+func (a singleValueAdapter) ExtractTokens(req *http.Request) ([]string, error) {
+	tok, err := a.Extractor.ExtractToken(req)
+	if err != nil {
+		return nil, err
+	}
+	return []string{tok}, nil
+}
+
+// AsMultiValueExtractor adapts any Extractor to a MultiValueExtractor, so
+// it can be used wherever multiple candidate tokens are expected. If e
+// already implements MultiValueExtractor, it's returned unchanged.
+func AsMultiValueExtractor(e Extractor) MultiValueExtractor {
+	if mv, ok := e.(MultiValueExtractor); ok {
+		return mv
+	}
+	return singleValueAdapter{e}
+}
+
+// ExtractTokens returns every non-empty value of each configured header, in
+// order, up to ExtractorLimit candidates.
+func (e HeaderExtractor) ExtractTokens(req *http.Request) ([]string, error) {
+	var tokens []string
+	for _, header := range e {
+		for _, v := range req.Header.Values(header) {
+			if v == "" {
+				continue
+			}
+			tokens = append(tokens, v)
+			if len(tokens) >= ExtractorLimit {
+				return tokens, nil
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNoTokenInRequest
+	}
+	return tokens, nil
+}
+
+// ExtractTokens returns every non-empty value of each configured form/query
+// argument, in order, up to ExtractorLimit candidates.
+func (e ArgumentExtractor) ExtractTokens(req *http.Request) ([]string, error) {
+	// Make sure form is parsed. We are explicitly ignoring errors at this point
+	_ = req.ParseMultipartForm(10e6)
+
+	var tokens []string
+	for _, arg := range e {
+		for _, v := range req.Form[arg] {
+			if v == "" {
+				continue
+			}
+			tokens = append(tokens, v)
+			if len(tokens) >= ExtractorLimit {
+				return tokens, nil
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNoTokenInRequest
+	}
+	return tokens, nil
+}
+
+// ExtractTokens returns every non-empty value of each configured cookie, in
+// order, up to ExtractorLimit candidates.
+func (e CookieExtractor) ExtractTokens(req *http.Request) ([]string, error) {
+	var tokens []string
+	for _, name := range e {
+		for _, c := range req.Cookies() {
+			if c.Name != name || c.Value == "" {
+				continue
+			}
+			tokens = append(tokens, c.Value)
+			if len(tokens) >= ExtractorLimit {
+				return tokens, nil
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNoTokenInRequest
+	}
+	return tokens, nil
+}
+
+// ParseFromRequest extracts every candidate token extractor can find in req
+// and calls verify on each in turn, returning the first candidate verify
+// accepts (nil error) without trying the rest. This is useful during key
+// rotation, or when a proxy injects an extra Authorization header: the
+// caller doesn't have to know in advance which candidate is the live one.
+//
+// If extractor finds no candidates, its error (typically ErrNoTokenInRequest)
+// is returned as-is. If every candidate fails verification, the errors from
+// every attempt are joined into one.
+func ParseFromRequest(req *http.Request, extractor MultiValueExtractor, verify func(token string) error) (string, error) {
+	tokens, err := extractor.ExtractTokens(req)
+	if err != nil {
+		return "", err
+	}
+
+	var errs []error
+	for _, tok := range tokens {
+		if verifyErr := verify(tok); verifyErr == nil {
+			return tok, nil
+		} else {
+			errs = append(errs, verifyErr)
+		}
+	}
+	return "", multiError(errs)
+}
+
+// multiError joins multiple verification failures into a single error,
+// without requiring errors.Join (Go 1.20+) and the module-version floor
+// that would silently impose.
+type multiError []error
+
+func (m multiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+// ExtractTokens collects candidate tokens from every child Extractor, in
+// order, up to ExtractorLimit candidates. An error from a child other than
+// ErrNoTokenInRequest aborts collection and is returned immediately.
+func (e MultiExtractor) ExtractTokens(req *http.Request) ([]string, error) {
+	var tokens []string
+	for _, extractor := range e {
+		toks, err := AsMultiValueExtractor(extractor).ExtractTokens(req)
+		if err != nil {
+			if errors.Is(err, ErrNoTokenInRequest) {
+				continue
+			}
+			return nil, err
+		}
+		tokens = append(tokens, toks...)
+		if len(tokens) >= ExtractorLimit {
+			return tokens[:ExtractorLimit], nil
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNoTokenInRequest
+	}
+	return tokens, nil
+}
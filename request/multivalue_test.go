@@ -0,0 +1,89 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHeaderExtractorExtractTokensCapped(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for i := 0; i < ExtractorLimit+5; i++ {
+		req.Header.Add("Authorization", "tok")
+	}
+
+	tokens, err := HeaderExtractor{"Authorization"}.ExtractTokens(req)
+	if err != nil {
+		t.Fatalf("ExtractTokens() unexpected error: %v", err)
+	}
+	if len(tokens) != ExtractorLimit {
+		t.Fatalf("ExtractTokens() returned %d tokens, want %d (ExtractorLimit)", len(tokens), ExtractorLimit)
+	}
+}
+
+func TestAsMultiValueExtractorSingleValue(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "tok-1")
+
+	mv := AsMultiValueExtractor(HeaderExtractor{"Authorization"})
+	tokens, err := mv.ExtractTokens(req)
+	if err != nil {
+		t.Fatalf("ExtractTokens() unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0] != "tok-1" {
+		t.Fatalf("ExtractTokens() = %v, want [tok-1]", tokens)
+	}
+}
+
+func TestParseFromRequestReturnsFirstVerifiedToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("Authorization", "bad-1")
+	req.Header.Add("Authorization", "good")
+	req.Header.Add("Authorization", "bad-2")
+
+	extractor := HeaderExtractor{"Authorization"}
+	verify := func(tok string) error {
+		if tok != "good" {
+			return errors.New("invalid token: " + tok)
+		}
+		return nil
+	}
+
+	tok, err := ParseFromRequest(req, extractor, verify)
+	if err != nil {
+		t.Fatalf("ParseFromRequest() unexpected error: %v", err)
+	}
+	if tok != "good" {
+		t.Fatalf("ParseFromRequest() = %q, want %q", tok, "good")
+	}
+}
+
+func TestParseFromRequestAggregatesErrorsWhenNoneVerify(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("Authorization", "bad-1")
+	req.Header.Add("Authorization", "bad-2")
+
+	extractor := HeaderExtractor{"Authorization"}
+	verify := func(tok string) error {
+		return errors.New("invalid token: " + tok)
+	}
+
+	_, err := ParseFromRequest(req, extractor, verify)
+	if err == nil {
+		t.Fatal("ParseFromRequest() error = nil, want aggregated error")
+	}
+	if !strings.Contains(err.Error(), "bad-1") || !strings.Contains(err.Error(), "bad-2") {
+		t.Fatalf("ParseFromRequest() error = %q, want it to mention both failures", err.Error())
+	}
+}
+
+func TestParseFromRequestNoCandidates(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	_, err := ParseFromRequest(req, HeaderExtractor{"Authorization"}, func(string) error { return nil })
+	if !errors.Is(err, ErrNoTokenInRequest) {
+		t.Fatalf("ParseFromRequest() error = %v, want ErrNoTokenInRequest", err)
+	}
+}
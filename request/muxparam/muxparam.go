@@ -0,0 +1,19 @@
+// Package muxparam adapts github.com/gorilla/mux route vars for use with
+// request.ParamExtractor. It's a separate module from the core request
+// package so that depending on it is opt-in: the core package doesn't pick
+// up gorilla/mux as a hard dependency just to support it as a router.
+package muxparam
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// VarsLookup is a request.ParamLookup backed by mux.Vars, for routes
+// registered on a mux.Router, e.g. "/download/{token}".
+//
+//	request.ParamExtractor{Names: []string{"token"}, Lookup: muxparam.VarsLookup}
+func VarsLookup(req *http.Request, name string) string {
+	return mux.Vars(req)[name]
+}
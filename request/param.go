@@ -0,0 +1,39 @@
+package request
+
+import "net/http"
+
+// ParamLookup looks up the value of a named path/route parameter from a
+// request, returning "" if it isn't set. net/http has no built-in concept
+// of path parameters, so this exists as a seam: each router (chi, gorilla/mux,
+// the http.ServeMux patterns added in Go 1.22, ...) exposes them its own way,
+// and this package shouldn't have to depend on any of them to support all of them.
+//
+// Out-of-the-box adapters:
+//
+//   - net/http's own router on Go 1.22+: PathValueLookup (pathvalue_go122.go)
+//   - chi: chiparam.URLParamLookup (request/chiparam), a separate module so
+//     depending on chi stays opt-in
+//   - gorilla/mux: muxparam.VarsLookup (request/muxparam), likewise a
+//     separate module
+//
+// Any other router can be wired up with a one-line ParamLookup of its own.
+type ParamLookup func(req *http.Request, name string) string
+
+// ParamExtractor pulls a token out of URL path parameters, e.g. "/ws/:token"
+// or "/download/{token}". Names are tried in order until there's a match.
+//
+// Lookup adapts to whichever router is in use; see ParamLookup for the
+// adapters shipped in and alongside this package.
+type ParamExtractor struct {
+	Names  []string
+	Lookup ParamLookup
+}
+
+func (e ParamExtractor) ExtractToken(req *http.Request) (string, error) {
+	for _, name := range e.Names {
+		if v := e.Lookup(req, name); v != "" {
+			return v, nil
+		}
+	}
+	return "", ErrNoTokenInRequest
+}
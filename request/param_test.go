@@ -0,0 +1,61 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParamExtractor(t *testing.T) {
+	lookup := func(params map[string]string) ParamLookup {
+		return func(req *http.Request, name string) string {
+			return params[name]
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		extractor ParamExtractor
+		wantToken string
+		wantErr   error
+	}{
+		{
+			name: "matches first configured name",
+			extractor: ParamExtractor{
+				Names:  []string{"token", "jwt"},
+				Lookup: lookup(map[string]string{"token": "tok-1", "jwt": "tok-2"}),
+			},
+			wantToken: "tok-1",
+		},
+		{
+			name: "falls through to later name when earlier is empty",
+			extractor: ParamExtractor{
+				Names:  []string{"token", "jwt"},
+				Lookup: lookup(map[string]string{"jwt": "tok-2"}),
+			},
+			wantToken: "tok-2",
+		},
+		{
+			name: "no configured name matches",
+			extractor: ParamExtractor{
+				Names:  []string{"token"},
+				Lookup: lookup(map[string]string{"other": "tok"}),
+			},
+			wantErr: ErrNoTokenInRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			tok, err := tc.extractor.ExtractToken(req)
+			if err != tc.wantErr {
+				t.Fatalf("ExtractToken() error = %v, want %v", err, tc.wantErr)
+			}
+			if tok != tc.wantToken {
+				t.Fatalf("ExtractToken() = %q, want %q", tok, tc.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+//go:build go1.22
+
+package request
+
+import "net/http"
+
+// PathValueLookup is a ParamLookup backed by http.Request.PathValue, added in
+// Go 1.22 for parameters in patterns registered on http.ServeMux (and routers
+// built on top of it), e.g. "/download/{token}".
+func PathValueLookup(req *http.Request, name string) string {
+	return req.PathValue(name)
+}
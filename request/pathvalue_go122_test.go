@@ -0,0 +1,24 @@
+//go:build go1.22
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPathValueLookup(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/download/abc", nil)
+	req.SetPathValue("token", "abc")
+
+	extractor := ParamExtractor{Names: []string{"token"}, Lookup: PathValueLookup}
+
+	tok, err := extractor.ExtractToken(req)
+	if err != nil {
+		t.Fatalf("ExtractToken() unexpected error: %v", err)
+	}
+	if tok != "abc" {
+		t.Fatalf("ExtractToken() = %q, want %q", tok, "abc")
+	}
+}